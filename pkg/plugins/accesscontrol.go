@@ -1,6 +1,8 @@
 package plugins
 
 import (
+	"context"
+
 	"github.com/grafana/grafana/pkg/models"
 	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/org"
@@ -9,9 +11,13 @@ import (
 
 const (
 	// Plugins actions
-	ActionInstall = "plugins:install"
-	ActionWrite   = "plugins:write"
-	ActionRead    = "plugins:read"
+	// ActionInstall only covers fetching and inspecting a plugin's
+	// privileges; completing the install additionally requires
+	// ActionInstallGrant, so a caller can't skip the privileges prompt.
+	ActionInstall      = "plugins:install"
+	ActionInstallGrant = "plugins:install:grant"
+	ActionWrite        = "plugins:write"
+	ActionRead         = "plugins:read"
 
 	// App Plugins actions
 	ActionAppAccess = "plugins.app:access"
@@ -20,6 +26,10 @@ const (
 	ClassBasedScopePrefix = "plugins:class:"
 	ExternalScope         = ClassBasedScopePrefix + "external"
 	CoreScope             = ClassBasedScopePrefix + "core"
+
+	// ID based scopes, one per installed plugin, for granting access to a
+	// single plugin's settings without handing out the whole class.
+	IDBasedScopePrefix = "plugins:id:"
 )
 
 var (
@@ -35,7 +45,11 @@ func AdminAccessEvaluator(cfg *setting.Cfg) ac.Evaluator {
 		return ac.EvalAny(
 			ac.EvalPermission(ActionWrite),
 			ac.EvalPermission(ActionInstall),
-			ac.EvalPermission(ActionRead, ClassBasedScopePrefix+"external")) // TODO check if bundle is needed
+			ac.EvalPermission(ActionRead, ClassBasedScopePrefix+"external"), // TODO check if bundle is needed
+			// A read grant on every plugin (plugins:*) is also enough to
+			// reach the page; a read grant scoped to a single plugin id is
+			// not.
+			ac.EvalPermission(ActionRead, ScopeProvider.GetResourceAllScope()))
 	}
 
 	// Plugin Admin is disabled  => No installation
@@ -49,13 +63,72 @@ func ReqCanAdminPlugins(cfg *setting.Cfg) func(rc *models.ReqContext) bool {
 	}
 }
 
-// Legacy handler that protects listing plugins
+// Legacy handler that protects listing plugins. Evaluates the RBAC scopes
+// ReadPluginEvaluator grants (class scope or matching id scope) first, and
+// falls back to the legacy org-role check for signed-in users who haven't
+// been granted either.
 func ReqCanReadPlugin(pluginDef PluginDTO) func(c *models.ReqContext) bool {
+	evaluator := ReadPluginEvaluator(pluginDef)
+
 	fallback := ac.ReqSignedIn
 	if !pluginDef.IsCorePlugin() {
 		fallback = ac.ReqHasRole(org.RoleAdmin)
 	}
-	return fallback
+
+	return func(c *models.ReqContext) bool {
+		if evaluator.Evaluate(c.SignedInUser.Permissions[c.OrgID]) {
+			return true
+		}
+
+		return fallback(c)
+	}
+}
+
+// ReadPluginEvaluator protects access to a single plugin's settings. It is
+// satisfied by the plugin's own class-based scope (plugins:class:core or
+// plugins:class:external) or by its per-plugin id scope, so teams can be
+// granted access to one plugin without inheriting its whole class.
+func ReadPluginEvaluator(pluginDef PluginDTO) ac.Evaluator {
+	classScope := ExternalScope
+	if pluginDef.IsCorePlugin() {
+		classScope = CoreScope
+	}
+
+	return ac.EvalAny(
+		ac.EvalPermission(ActionRead, classScope),
+		ac.EvalPermission(ActionRead, ScopeProvider.GetResourceScope(pluginDef.ID)),
+	)
+}
+
+// classScopeResolver expands a plugins:class:external / plugins:class:core
+// scope into itself plus the plugins:id:<pluginID> scope of every plugin in
+// that class. Existing class-based grants need no data migration:
+// ReadPluginEvaluator still accepts the class scope itself.
+func classScopeResolver(store Store) ac.ScopeAttributeResolver {
+	return ac.ScopeAttributeResolverFunc(func(ctx context.Context, _ int64, initialScope string) ([]string, error) {
+		wantCore := initialScope == CoreScope
+
+		scopes := []string{initialScope}
+		for _, pluginDef := range store.Plugins(ctx) {
+			if pluginDef.IsCorePlugin() == wantCore {
+				scopes = append(scopes, ScopeProvider.GetResourceScope(pluginDef.ID))
+			}
+		}
+
+		return scopes, nil
+	})
+}
+
+// Store is the narrow view of the plugin registry the class scope resolver
+// needs: enough to tell which plugins a class covers.
+type Store interface {
+	Plugins(ctx context.Context) []PluginDTO
+}
+
+// RegisterScopeResolver registers classScopeResolver with the accesscontrol
+// service for scopes under the plugins:class: prefix.
+func RegisterScopeResolver(service ac.Service, store Store) {
+	service.RegisterScopeAttributeResolver(ClassBasedScopePrefix, classScopeResolver(store))
 }
 
 func DeclareRBACRoles(service ac.Service, cfg *setting.Cfg) error {
@@ -103,6 +176,7 @@ func DeclareRBACRoles(service ac.Service, cfg *setting.Cfg) error {
 			Group:       "Plugins",
 			Permissions: []ac.Permission{
 				{Action: ActionInstall},
+				{Action: ActionInstallGrant},
 			},
 		},
 		Grants: []string{ac.RoleGrafanaAdmin},
@@ -112,6 +186,22 @@ func DeclareRBACRoles(service ac.Service, cfg *setting.Cfg) error {
 		PluginsMaintainer.Grants = []string{}
 	}
 
+	// PluginsInspector can fetch and inspect a plugin's declared privileges
+	// but can't accept them and complete the install, so an admin can review
+	// what a plugin will be allowed to do before a Plugin Maintainer grants it.
+	PluginsInspector := ac.RoleRegistration{
+		Role: ac.RoleDTO{
+			Name:        ac.FixedRolePrefix + "plugins:inspector",
+			DisplayName: "Plugin Inspector",
+			Description: "Inspect the privileges a plugin requests before it is installed",
+			Group:       "Plugins",
+			Permissions: []ac.Permission{
+				{Action: ActionInstall},
+			},
+		},
+		Grants: []string{string(org.RoleAdmin), ac.RoleGrafanaAdmin},
+	}
+
 	PluginsExternalReader := ac.RoleRegistration{
 		Role: ac.RoleDTO{
 			Name:        ac.FixedRolePrefix + "plugins.external:reader",
@@ -125,5 +215,36 @@ func DeclareRBACRoles(service ac.Service, cfg *setting.Cfg) error {
 		Grants: []string{string(org.RoleAdmin), ac.RoleGrafanaAdmin},
 	}
 
-	return service.DeclareFixedRoles(AppPluginsReader, PluginsReader, PluginsWriter, PluginsMaintainer, PluginsExternalReader)
+	// PluginsIDReader and PluginsIDWriter are resource-scoped roles: their
+	// scope carries a :pluginId parameter, so they're not granted to a base
+	// role here. Assign them to a specific plugin ID through the same
+	// resourcepermissions flow used for datasources and folders.
+	PluginsIDReader := ac.RoleRegistration{
+		Role: ac.RoleDTO{
+			Name:        ac.FixedRolePrefix + "plugins:id.reader",
+			DisplayName: "Plugin Reader",
+			Description: "Read a single plugin's settings",
+			Group:       "Plugins",
+			Permissions: []ac.Permission{
+				{Action: ActionRead, Scope: ScopeProvider.GetResourceScope(ac.Parameter(":pluginId"))},
+			},
+		},
+		Grants: []string{},
+	}
+	PluginsIDWriter := ac.RoleRegistration{
+		Role: ac.RoleDTO{
+			Name:        ac.FixedRolePrefix + "plugins:id.writer",
+			DisplayName: "Plugin Writer",
+			Description: "Edit a single plugin's settings",
+			Group:       "Plugins",
+			Permissions: []ac.Permission{
+				{Action: ActionWrite, Scope: ScopeProvider.GetResourceScope(ac.Parameter(":pluginId"))},
+			},
+		},
+		Grants: []string{},
+	}
+
+	return service.DeclareFixedRoles(
+		AppPluginsReader, PluginsReader, PluginsWriter, PluginsMaintainer, PluginsExternalReader,
+		PluginsIDReader, PluginsIDWriter, PluginsInspector)
 }
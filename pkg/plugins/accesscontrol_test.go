@@ -0,0 +1,30 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPluginEvaluator(t *testing.T) {
+	pluginDef := PluginDTO{ID: "my-plugin", Class: External}
+
+	t.Run("class scope grants access", func(t *testing.T) {
+		permissions := map[string][]string{ActionRead: {ExternalScope}}
+		require.True(t, ReadPluginEvaluator(pluginDef).Evaluate(permissions))
+	})
+
+	t.Run("matching id scope grants access without the class scope", func(t *testing.T) {
+		permissions := map[string][]string{ActionRead: {ScopeProvider.GetResourceScope(pluginDef.ID)}}
+		require.True(t, ReadPluginEvaluator(pluginDef).Evaluate(permissions))
+	})
+
+	t.Run("an id scope for a different plugin does not grant access", func(t *testing.T) {
+		permissions := map[string][]string{ActionRead: {ScopeProvider.GetResourceScope("other-plugin")}}
+		require.False(t, ReadPluginEvaluator(pluginDef).Evaluate(permissions))
+	})
+
+	t.Run("no matching scope at all does not grant access", func(t *testing.T) {
+		require.False(t, ReadPluginEvaluator(pluginDef).Evaluate(map[string][]string{}))
+	})
+}
@@ -0,0 +1,191 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+)
+
+// PluginRef identifies the plugin an install flow is operating on: the
+// plugin ID and the version (or URL) the caller asked for.
+type PluginRef struct {
+	PluginID string
+	Version  string
+}
+
+// PluginPrivilege is a single capability a plugin's manifest requests, e.g.
+// permission to execute a backend binary, reach a specific data source, make
+// outgoing HTTP calls, or read the filesystem. Modeled after Docker's plugin
+// Privilege: Installer.Privileges surfaces these to the caller the same way
+// `docker plugin install` lists Privileges before Pull.
+type PluginPrivilege struct {
+	Name        string
+	Description string
+	Value       []string
+}
+
+// PluginPrivileges is everything Installer.Privileges parses out of a
+// plugin's manifest before it is installed.
+type PluginPrivileges struct {
+	PluginID   string
+	Signature  string
+	Routes     []string
+	Actions    []string
+	Privileges []PluginPrivilege
+}
+
+// ErrPrivilegesMismatch is returned by Installer.Install when the caller's
+// acceptedPrivileges don't match what Privileges reported for the plugin, so
+// the UI can re-fetch and re-prompt instead of installing with a stale or
+// narrower acceptance than the plugin actually requests.
+type ErrPrivilegesMismatch struct {
+	PluginID string
+	Reason   string
+}
+
+func (e *ErrPrivilegesMismatch) Error() string {
+	return fmt.Sprintf("accepted privileges for plugin %q do not match what it declares: %s", e.PluginID, e.Reason)
+}
+
+// Installer installs plugins through a two-step flow: Privileges fetches and
+// inspects a plugin's manifest without installing anything, and Install
+// refuses to proceed unless the caller has accepted exactly the privileges
+// Privileges reported.
+type Installer interface {
+	// Privileges fetches ref's manifest and returns the privileges it
+	// declares.
+	Privileges(ctx context.Context, ref PluginRef) (PluginPrivileges, error)
+	// Install installs ref. acceptedPrivileges must match the set most
+	// recently returned by Privileges(ctx, ref), or Install returns
+	// *ErrPrivilegesMismatch instead of installing.
+	Install(ctx context.Context, ref PluginRef, acceptedPrivileges []PluginPrivilege) error
+}
+
+// validateAcceptedPrivileges checks that accepted is exactly the set of
+// privileges in declared: same names, same values, each declared privilege
+// covered exactly once. It does not allow a caller to drop, duplicate, or
+// narrow a privilege the plugin actually requests.
+func validateAcceptedPrivileges(pluginID string, declared, accepted []PluginPrivilege) error {
+	if len(accepted) != len(declared) {
+		return &ErrPrivilegesMismatch{
+			PluginID: pluginID,
+			Reason:   fmt.Sprintf("expected %d privileges, got %d", len(declared), len(accepted)),
+		}
+	}
+
+	declaredByName := make(map[string]PluginPrivilege, len(declared))
+	for _, p := range declared {
+		declaredByName[p.Name] = p
+	}
+
+	matched := make(map[string]bool, len(declared))
+	for _, a := range accepted {
+		if matched[a.Name] {
+			return &ErrPrivilegesMismatch{PluginID: pluginID, Reason: fmt.Sprintf("privilege %q accepted more than once", a.Name)}
+		}
+
+		d, ok := declaredByName[a.Name]
+		if !ok {
+			return &ErrPrivilegesMismatch{PluginID: pluginID, Reason: fmt.Sprintf("unknown privilege %q", a.Name)}
+		}
+
+		if !equalPrivilegeValues(d.Value, a.Value) {
+			return &ErrPrivilegesMismatch{PluginID: pluginID, Reason: fmt.Sprintf("privilege %q was downgraded", a.Name)}
+		}
+
+		matched[a.Name] = true
+	}
+
+	if len(matched) != len(declared) {
+		return &ErrPrivilegesMismatch{PluginID: pluginID, Reason: "not every declared privilege was accepted"}
+	}
+
+	return nil
+}
+
+// equalPrivilegeValues compares two privilege value sets ignoring order, so
+// a UI that round-trips or re-renders Value in a different order doesn't
+// get flagged as a downgrade.
+func equalPrivilegeValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for _, v := range a {
+		counts[v]++
+	}
+
+	for _, v := range b {
+		counts[v]--
+		if counts[v] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Manifest is the subset of a plugin's manifest privileges are parsed from.
+type Manifest struct {
+	PluginID   string
+	Signature  string
+	Routes     []string
+	Actions    []string
+	Privileges []PluginPrivilege
+}
+
+// ManifestFetcher fetches and parses a plugin's manifest without installing
+// anything, e.g. by downloading the plugin archive, verifying its signature,
+// and extracting the permissions, routes and RBAC actions it declares.
+type ManifestFetcher interface {
+	FetchManifest(ctx context.Context, ref PluginRef) (Manifest, error)
+}
+
+// PluginUnpacker performs an already-accepted plugin install: downloading
+// and unpacking ref onto disk.
+type PluginUnpacker interface {
+	UnpackPlugin(ctx context.Context, ref PluginRef) error
+}
+
+// manifestInstaller is the default Installer. It fetches a plugin's
+// manifest through fetcher, and only calls through to unpacker once the
+// caller's accepted privileges have been validated against what the
+// manifest declares.
+type manifestInstaller struct {
+	fetcher  ManifestFetcher
+	unpacker PluginUnpacker
+}
+
+// NewInstaller builds an Installer that parses privileges out of manifests
+// fetched through fetcher, and unpacks accepted installs through unpacker.
+func NewInstaller(fetcher ManifestFetcher, unpacker PluginUnpacker) Installer {
+	return &manifestInstaller{fetcher: fetcher, unpacker: unpacker}
+}
+
+func (i *manifestInstaller) Privileges(ctx context.Context, ref PluginRef) (PluginPrivileges, error) {
+	m, err := i.fetcher.FetchManifest(ctx, ref)
+	if err != nil {
+		return PluginPrivileges{}, fmt.Errorf("failed to fetch manifest for plugin %q: %w", ref.PluginID, err)
+	}
+
+	return PluginPrivileges{
+		PluginID:   m.PluginID,
+		Signature:  m.Signature,
+		Routes:     m.Routes,
+		Actions:    m.Actions,
+		Privileges: m.Privileges,
+	}, nil
+}
+
+func (i *manifestInstaller) Install(ctx context.Context, ref PluginRef, acceptedPrivileges []PluginPrivilege) error {
+	declared, err := i.Privileges(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	if err := validateAcceptedPrivileges(ref.PluginID, declared.Privileges, acceptedPrivileges); err != nil {
+		return err
+	}
+
+	return i.unpacker.UnpackPlugin(ctx, ref)
+}
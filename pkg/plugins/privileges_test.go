@@ -0,0 +1,134 @@
+package plugins
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAcceptedPrivileges(t *testing.T) {
+	declared := []PluginPrivilege{
+		{Name: "backend-exec", Value: []string{"true"}},
+		{Name: "outgoing-http", Value: []string{"https://example.com"}},
+	}
+
+	t.Run("exact match is accepted", func(t *testing.T) {
+		accepted := []PluginPrivilege{
+			{Name: "outgoing-http", Value: []string{"https://example.com"}},
+			{Name: "backend-exec", Value: []string{"true"}},
+		}
+		require.NoError(t, validateAcceptedPrivileges("my-plugin", declared, accepted))
+	})
+
+	t.Run("dropped privilege is rejected", func(t *testing.T) {
+		accepted := []PluginPrivilege{{Name: "backend-exec", Value: []string{"true"}}}
+		err := validateAcceptedPrivileges("my-plugin", declared, accepted)
+		require.Error(t, err)
+		require.ErrorAs(t, err, new(*ErrPrivilegesMismatch))
+	})
+
+	t.Run("duplicated privilege standing in for a dropped one is rejected", func(t *testing.T) {
+		accepted := []PluginPrivilege{
+			{Name: "backend-exec", Value: []string{"true"}},
+			{Name: "backend-exec", Value: []string{"true"}},
+		}
+		err := validateAcceptedPrivileges("my-plugin", declared, accepted)
+		require.Error(t, err)
+		require.ErrorAs(t, err, new(*ErrPrivilegesMismatch))
+	})
+
+	t.Run("downgraded value is rejected", func(t *testing.T) {
+		accepted := []PluginPrivilege{
+			{Name: "backend-exec", Value: []string{"true"}},
+			{Name: "outgoing-http", Value: []string{"https://narrower.example.com"}},
+		}
+		err := validateAcceptedPrivileges("my-plugin", declared, accepted)
+		require.Error(t, err)
+		require.ErrorAs(t, err, new(*ErrPrivilegesMismatch))
+	})
+
+	t.Run("unknown privilege is rejected", func(t *testing.T) {
+		accepted := []PluginPrivilege{
+			{Name: "backend-exec", Value: []string{"true"}},
+			{Name: "outgoing-http", Value: []string{"https://example.com"}},
+			{Name: "filesystem-access", Value: []string{"true"}},
+		}
+		err := validateAcceptedPrivileges("my-plugin", declared, accepted)
+		require.Error(t, err)
+		require.ErrorAs(t, err, new(*ErrPrivilegesMismatch))
+	})
+
+	t.Run("reordered values are still an exact match", func(t *testing.T) {
+		declared := []PluginPrivilege{
+			{Name: "outgoing-http", Value: []string{"https://a.example.com", "https://b.example.com"}},
+		}
+		accepted := []PluginPrivilege{
+			{Name: "outgoing-http", Value: []string{"https://b.example.com", "https://a.example.com"}},
+		}
+		require.NoError(t, validateAcceptedPrivileges("my-plugin", declared, accepted))
+	})
+}
+
+type fakeManifestFetcher struct {
+	manifest Manifest
+	err      error
+}
+
+func (f *fakeManifestFetcher) FetchManifest(_ context.Context, _ PluginRef) (Manifest, error) {
+	return f.manifest, f.err
+}
+
+type fakeUnpacker struct {
+	called bool
+	err    error
+}
+
+func (f *fakeUnpacker) UnpackPlugin(_ context.Context, _ PluginRef) error {
+	f.called = true
+	return f.err
+}
+
+func TestManifestInstaller_Install(t *testing.T) {
+	ref := PluginRef{PluginID: "my-plugin", Version: "1.0.0"}
+	declared := []PluginPrivilege{{Name: "backend-exec", Value: []string{"true"}}}
+
+	t.Run("installs once accepted privileges match", func(t *testing.T) {
+		fetcher := &fakeManifestFetcher{manifest: Manifest{PluginID: ref.PluginID, Privileges: declared}}
+		unpacker := &fakeUnpacker{}
+		installer := NewInstaller(fetcher, unpacker)
+
+		err := installer.Install(context.Background(), ref, declared)
+		require.NoError(t, err)
+		require.True(t, unpacker.called)
+	})
+
+	t.Run("refuses to install on mismatched privileges", func(t *testing.T) {
+		fetcher := &fakeManifestFetcher{manifest: Manifest{PluginID: ref.PluginID, Privileges: declared}}
+		unpacker := &fakeUnpacker{}
+		installer := NewInstaller(fetcher, unpacker)
+
+		err := installer.Install(context.Background(), ref, nil)
+		require.Error(t, err)
+		require.ErrorAs(t, err, new(*ErrPrivilegesMismatch))
+		require.False(t, unpacker.called, "must not unpack when privileges don't match")
+	})
+
+	t.Run("Privileges surfaces what the manifest declares", func(t *testing.T) {
+		fetcher := &fakeManifestFetcher{manifest: Manifest{
+			PluginID:   ref.PluginID,
+			Signature:  "valid",
+			Routes:     []string{"/api"},
+			Actions:    []string{"my-plugin:read"},
+			Privileges: declared,
+		}}
+		installer := NewInstaller(fetcher, &fakeUnpacker{})
+
+		got, err := installer.Privileges(context.Background(), ref)
+		require.NoError(t, err)
+		require.Equal(t, ref.PluginID, got.PluginID)
+		require.Equal(t, "valid", got.Signature)
+		require.Equal(t, []string{"/api"}, got.Routes)
+		require.Equal(t, declared, got.Privileges)
+	})
+}
@@ -0,0 +1,141 @@
+package toucan
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// subscriberBufferSize is how many events a subscriber can lag behind before
+// the broker starts dropping its oldest buffered events.
+const subscriberBufferSize = 16
+
+// Filter decides whether a subscriber is interested in an event. A nil
+// Filter matches every event.
+type Filter func(Event) bool
+
+// EventTypes returns a Filter matching any of the given event types.
+func EventTypes(types ...string) Filter {
+	set := make(map[string]bool, len(types))
+	for _, t := range types {
+		set[t] = true
+	}
+
+	return func(e Event) bool { return set[e.EventType()] }
+}
+
+type subscriber struct {
+	id     int64
+	ch     chan Event
+	filter Filter
+}
+
+// broker fans events out to subscribers from a single goroutine, so
+// subscribe, cancel and publish never race each other. Publishing never
+// blocks: a subscriber that falls behind has its oldest buffered event
+// dropped to make room for the new one.
+type broker struct {
+	nextID      int64
+	subscribeCh chan *subscriber
+	cancelCh    chan int64
+	publishCh   chan Event
+	doneCh      chan struct{}
+	closeOnce   sync.Once
+}
+
+func newBroker() *broker {
+	b := &broker{
+		subscribeCh: make(chan *subscriber),
+		cancelCh:    make(chan int64),
+		publishCh:   make(chan Event),
+		doneCh:      make(chan struct{}),
+	}
+	go b.run()
+
+	return b
+}
+
+func (b *broker) run() {
+	subs := make(map[int64]*subscriber)
+
+	for {
+		select {
+		case sub := <-b.subscribeCh:
+			subs[sub.id] = sub
+		case id := <-b.cancelCh:
+			if sub, ok := subs[id]; ok {
+				delete(subs, id)
+				close(sub.ch)
+			}
+		case e := <-b.publishCh:
+			for _, sub := range subs {
+				if sub.filter != nil && !sub.filter(e) {
+					continue
+				}
+
+				select {
+				case sub.ch <- e:
+				default:
+					// Slow consumer: drop the oldest buffered event to make
+					// room for this one rather than block the publisher.
+					select {
+					case <-sub.ch:
+					default:
+					}
+
+					select {
+					case sub.ch <- e:
+					default:
+					}
+				}
+			}
+		case <-b.doneCh:
+			for _, sub := range subs {
+				close(sub.ch)
+			}
+
+			return
+		}
+	}
+}
+
+// subscribe registers filter and returns a channel of matching events and a
+// cancel func that unsubscribes and closes the channel. A nil filter
+// subscribes to every event. cancel is safe to call more than once.
+func (b *broker) subscribe(filter Filter) (<-chan Event, func()) {
+	sub := &subscriber{
+		id:     atomic.AddInt64(&b.nextID, 1),
+		ch:     make(chan Event, subscriberBufferSize),
+		filter: filter,
+	}
+
+	select {
+	case b.subscribeCh <- sub:
+	case <-b.doneCh:
+		close(sub.ch)
+	}
+
+	var cancelOnce sync.Once
+	cancel := func() {
+		cancelOnce.Do(func() {
+			select {
+			case b.cancelCh <- sub.id:
+			case <-b.doneCh:
+			}
+		})
+	}
+
+	return sub.ch, cancel
+}
+
+func (b *broker) publish(e Event) {
+	select {
+	case b.publishCh <- e:
+	case <-b.doneCh:
+	}
+}
+
+// close shuts the broker down, closing every subscriber channel. Safe to
+// call more than once.
+func (b *broker) close() {
+	b.closeOnce.Do(func() { close(b.doneCh) })
+}
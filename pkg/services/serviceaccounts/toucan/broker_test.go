@@ -0,0 +1,142 @@
+package toucan
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent struct {
+	baseEvent
+	n int
+}
+
+func newTestEvent(n int) testEvent {
+	return testEvent{baseEvent{"test"}, n}
+}
+
+func TestBroker_PublishSubscribe(t *testing.T) {
+	b := newBroker()
+	defer b.close()
+
+	ch, cancel := b.subscribe(nil)
+	defer cancel()
+
+	b.publish(newTestEvent(1))
+
+	select {
+	case e := <-ch:
+		require.Equal(t, testEvent{baseEvent{"test"}, 1}, e)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
+func TestBroker_FilterExcludesNonMatchingEvents(t *testing.T) {
+	b := newBroker()
+	defer b.close()
+
+	ch, cancel := b.subscribe(EventTypes(EventTypeTokenRevoked))
+	defer cancel()
+
+	b.publish(newTestEvent(1))
+	b.publish(newTokenRevoked(Credential{OrgID: 1}))
+
+	select {
+	case e := <-ch:
+		require.Equal(t, EventTypeTokenRevoked, e.EventType())
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	select {
+	case e := <-ch:
+		t.Fatalf("unexpected second event: %#v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBroker_CancelClosesChannelAndIsIdempotent(t *testing.T) {
+	b := newBroker()
+	defer b.close()
+
+	ch, cancel := b.subscribe(nil)
+	cancel()
+	cancel() // must not panic or block
+
+	_, ok := <-ch
+	require.False(t, ok, "channel should be closed after cancel")
+}
+
+func TestBroker_SlowConsumerDropsOldestEvent(t *testing.T) {
+	b := newBroker()
+	defer b.close()
+
+	ch, cancel := b.subscribe(nil)
+	defer cancel()
+
+	// Publish more events than the subscriber's buffer can hold without the
+	// subscriber ever reading, so the broker must drop the oldest ones
+	// instead of blocking.
+	total := subscriberBufferSize + 5
+	for i := 0; i < total; i++ {
+		b.publish(newTestEvent(i))
+	}
+
+	// subscribe only returns once the broker loop has finished handing out
+	// the last published event, so nothing is still in flight while the
+	// channel is drained below.
+	_, barrier := b.subscribe(nil)
+	barrier()
+
+	var got []int
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				t.Fatal("channel closed unexpectedly")
+			}
+			got = append(got, e.(testEvent).n)
+		case <-time.After(100 * time.Millisecond):
+			require.LessOrEqual(t, len(got), subscriberBufferSize)
+			require.Equal(t, total-1, got[len(got)-1], "the most recent event should have survived")
+			return
+		}
+	}
+}
+
+func TestBroker_ConcurrentSubscribeCancelPublish(t *testing.T) {
+	b := newBroker()
+	defer b.close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			ch, cancel := b.subscribe(nil)
+			defer cancel()
+
+			b.publish(newTestEvent(n))
+
+			select {
+			case <-ch:
+			case <-time.After(time.Second):
+			}
+		}(i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for concurrent subscribers")
+	}
+}
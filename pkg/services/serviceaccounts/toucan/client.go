@@ -0,0 +1,80 @@
+package toucan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const githubProviderName = "github"
+
+// githubBatchSize is the maximum number of hashes GitHub's secret alert
+// service accepts per request.
+const githubBatchSize = 100
+
+// githubDefaultTimeout bounds a single Check call against GitHub's secret
+// scanning endpoint, used unless [secret_scan.github] overrides it.
+const githubDefaultTimeout = 10 * time.Second
+
+// githubProvider checks token hashes against GitHub's secret scanning
+// partner program. It is the provider toucan has always shipped with.
+type githubProvider struct {
+	httpClient *http.Client
+	url        string
+	timeout    time.Duration
+}
+
+func newGithubProvider(timeout time.Duration) *githubProvider {
+	return &githubProvider{
+		httpClient: &http.Client{},
+		url:        "https://api.github.com/partner-upload/token-scanning/validate",
+		timeout:    timeout,
+	}
+}
+
+func (p *githubProvider) Name() string {
+	return githubProviderName
+}
+
+func (p *githubProvider) BatchSize() int {
+	return githubBatchSize
+}
+
+func (p *githubProvider) Timeout() time.Duration {
+	return p.timeout
+}
+
+func (p *githubProvider) Check(ctx context.Context, hashes []string) ([]string, error) {
+	body, err := json.Marshal(map[string][]string{"hashes": hashes})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check tokens: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from github secret scanning: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Leaked []string `json:"leaked_hashes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Leaked, nil
+}
@@ -0,0 +1,24 @@
+package toucan
+
+import (
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// providersFromConfig builds the set of enabled providers from the Grafana
+// config. The built-in GitHub provider is the only one it knows: it reads
+// the [secret_scan.github] section, where the provider is enabled unless
+// enabled is set to false (so upgrades keep today's behavior) and timeout
+// overrides githubDefaultTimeout. No other [secret_scan.*] section is read.
+func providersFromConfig(cfg *setting.Cfg) []Provider {
+	if cfg == nil {
+		return []Provider{newGithubProvider(githubDefaultTimeout)}
+	}
+
+	section := cfg.SectionWithEnvOverrides("secret_scan.github")
+	if section.Key("enabled").MustBool(true) {
+		timeout := section.Key("timeout").MustDuration(githubDefaultTimeout)
+		return []Provider{newGithubProvider(timeout)}
+	}
+
+	return nil
+}
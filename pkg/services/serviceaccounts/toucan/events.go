@@ -0,0 +1,84 @@
+package toucan
+
+import "time"
+
+// Event types published on the Service's event bus.
+const (
+	EventTypeTokenCheckStarted     = "token_check_started"
+	EventTypeTokenLeaked           = "token_leaked"
+	EventTypeTokenRevoked          = "token_revoked"
+	EventTypeTokenRevocationFailed = "token_revocation_failed"
+)
+
+// Event is anything the broker can publish. Subscribers switch on the
+// concrete type, or filter on EventType() without knowing it.
+type Event interface {
+	EventType() string
+}
+
+type baseEvent struct {
+	eventType string
+}
+
+func (e baseEvent) EventType() string { return e.eventType }
+
+// TokenCheckStarted is published once per CheckTokens run, before any source
+// or provider is queried.
+type TokenCheckStarted struct {
+	baseEvent
+	StartedAt time.Time
+}
+
+func newTokenCheckStarted() TokenCheckStarted {
+	return TokenCheckStarted{baseEvent{EventTypeTokenCheckStarted}, time.Now()}
+}
+
+// TokenLeaked is published for every credential a provider flags as leaked,
+// before toucan attempts to revoke it.
+type TokenLeaked struct {
+	baseEvent
+	OrgID            int64
+	ServiceAccountID int64
+	TokenID          int64
+	Provider         string
+	DetectedAt       time.Time
+}
+
+func newTokenLeaked(c Credential, provider string) TokenLeaked {
+	return TokenLeaked{
+		baseEvent:        baseEvent{EventTypeTokenLeaked},
+		OrgID:            c.OrgID,
+		ServiceAccountID: c.OwnerID,
+		TokenID:          c.ID,
+		Provider:         provider,
+		DetectedAt:       time.Now(),
+	}
+}
+
+// TokenRevoked is published once a leaked credential has been revoked.
+type TokenRevoked struct {
+	baseEvent
+	OrgID            int64
+	ServiceAccountID int64
+	TokenID          int64
+}
+
+func newTokenRevoked(c Credential) TokenRevoked {
+	return TokenRevoked{baseEvent{EventTypeTokenRevoked}, c.OrgID, c.OwnerID, c.ID}
+}
+
+// TokenRevocationFailed is published when revoking a leaked credential
+// fails. CheckTokens still returns the error; this lets subscribers that
+// only care about revocation outcomes avoid threading error handling
+// through the caller of CheckTokens.
+type TokenRevocationFailed struct {
+	baseEvent
+	OrgID            int64
+	ServiceAccountID int64
+	TokenID          int64
+	Err              error
+}
+
+func newTokenRevocationFailed(c Credential, err error) TokenRevocationFailed {
+	return TokenRevocationFailed{baseEvent{EventTypeTokenRevocationFailed}, c.OrgID, c.OwnerID, c.ID, err}
+}
@@ -0,0 +1,50 @@
+package toucan
+
+import (
+	"context"
+	"time"
+)
+
+// Provider checks a batch of token hashes against a leaked-secrets source and
+// reports back the subset that was found to be leaked. Implementations wrap a
+// single upstream: GitHub secret scanning, GitLab secret detection, an
+// internal honeypot feed, a local offline bloom filter, etc.
+type Provider interface {
+	// Name identifies the provider in logs and in LeakedHash.Provider.
+	Name() string
+	// Check returns the hashes from the input that the provider considers leaked.
+	// Callers are expected to respect BatchSize when slicing hashes.
+	Check(ctx context.Context, hashes []string) ([]string, error)
+	// BatchSize is the largest number of hashes the provider accepts in a
+	// single Check call. A value <= 0 means no limit.
+	BatchSize() int
+	// Timeout bounds a single Check call. A slow remote provider and a fast
+	// local one don't have to share one bound.
+	Timeout() time.Duration
+}
+
+// LeakedHash is a hash reported as leaked, tagged with the provider that
+// flagged it so callers can record provenance alongside the revocation.
+type LeakedHash struct {
+	Hash     string
+	Provider string
+}
+
+// chunk splits hashes into batches of at most size, preserving order.
+// A size <= 0 means no limit, so hashes is returned as a single batch.
+func chunk(hashes []string, size int) [][]string {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	if size <= 0 || size >= len(hashes) {
+		return [][]string{hashes}
+	}
+
+	batches := make([][]string, 0, (len(hashes)+size-1)/size)
+	for size < len(hashes) {
+		hashes, batches = hashes[size:], append(batches, hashes[0:size:size])
+	}
+
+	return append(batches, hashes)
+}
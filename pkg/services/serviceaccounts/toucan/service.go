@@ -8,6 +8,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/apikey"
 	"github.com/grafana/grafana/pkg/services/serviceaccounts"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
 type Checker interface {
@@ -21,29 +22,48 @@ type SATokenRetriever interface {
 
 // Toucan Service is grafana's service for checking leaked keys.
 type Service struct {
-	store  SATokenRetriever
-	client *client
-	logger log.Logger
+	sources   []TokenSource
+	providers []Provider
+	events    *broker
+	logger    log.Logger
 }
 
-func NewService(store SATokenRetriever) *Service {
-	return &Service{
-		store:  store,
-		client: newClient(),
-		logger: log.New("toucan"),
-	}
+// ProvideService builds a Service with the providers enabled in cfg,
+// checking every credential store Grafana knows about: service account
+// tokens, legacy API keys, data source secrets, and SMTP/alerting
+// credentials.
+func ProvideService(
+	saTokens SATokenRetriever,
+	legacyAPIKeys LegacyAPIKeyRetriever,
+	dataSourceSecrets DataSourceSecretRetriever,
+	notifierSecrets NotifierSecretRetriever,
+	cfg *setting.Cfg,
+) *Service {
+	return NewService([]TokenSource{
+		newSATokenSource(saTokens),
+		newLegacyAPIKeySource(legacyAPIKeys),
+		newDataSourceSecretSource(dataSourceSecrets),
+		newNotifierSecretSource(notifierSecrets),
+	}, providersFromConfig(cfg))
 }
 
-func (s *Service) RetrieveActiveTokens(ctx context.Context) ([]apikey.APIKey, error) {
-	saTokens, err := s.store.ListTokens(ctx, &serviceaccounts.GetSATokensQuery{
-		OrgID:            nil,
-		ServiceAccountID: nil,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve service account tokens: %w", err)
+// NewService builds a Service that checks the credentials listed by sources
+// against providers. Each provider bounds its own Check calls via Timeout.
+func NewService(sources []TokenSource, providers []Provider) *Service {
+	return &Service{
+		sources:   sources,
+		providers: providers,
+		events:    newBroker(),
+		logger:    log.New("toucan"),
 	}
+}
 
-	return saTokens, nil
+// Subscribe returns a channel of events matching filter, and a cancel func
+// that unsubscribes and closes the channel. A nil filter subscribes to
+// every event. The channel is buffered; a subscriber that falls behind
+// misses older events rather than blocking CheckTokens.
+func (s *Service) Subscribe(filter Filter) (<-chan Event, func()) {
+	return s.events.subscribe(filter)
 }
 
 // hasExpired returns true if the token has expired.
@@ -58,42 +78,107 @@ func hasExpired(expiration *int64) bool {
 	return (v).Before(time.Now())
 }
 
-// CheckTokens checks for leaked tokens.
+// CheckTokens checks every registered source's credentials for leaks.
 func (s *Service) CheckTokens(ctx context.Context) error {
-	// Retrieve all active tokens from the database.
-	tokens, err := s.RetrieveActiveTokens(ctx)
+	s.events.publish(newTokenCheckStarted())
+
+	hashes, credsByHash, err := s.listActiveCredentials(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve tokens for checking: %w", err)
 	}
 
-	hashes := make([]string, 0, len(tokens))
-	hashMap := make(map[string]apikey.APIKey)
+	// Check if any leaked tokens exist, across every configured provider.
+	leaked, err := s.checkProviders(ctx, hashes)
+	if err != nil {
+		return fmt.Errorf("failed to check tokens: %w", err)
+	}
 
-	for _, token := range tokens {
-		if hasExpired(token.Expires) {
+	// Revoke leaked tokens.
+	// Could be done in bulk but we don't expect more than 1 or 2 tokens to be leaked per check.
+	for _, l := range leaked {
+		cs, ok := credsByHash[l.Hash]
+		if !ok {
 			continue
 		}
 
-		hashes = append(hashes, token.Key)
-		hashMap[token.Key] = token
+		s.events.publish(newTokenLeaked(cs.cred, l.Provider))
+
+		if err := cs.source.Revoke(ctx, cs.cred); err != nil {
+			s.events.publish(newTokenRevocationFailed(cs.cred, err))
+			return fmt.Errorf("failed to revoke leaked %s credential: %w", cs.cred.Kind, err)
+		}
+
+		s.events.publish(newTokenRevoked(cs.cred))
+		s.logger.Warn("revoked leaked credential",
+			"kind", cs.cred.Kind, "owner", cs.cred.Owner, "provider", l.Provider)
 	}
 
-	// Check if any leaked tokens exist.
-	leakedTokenHashes, err := s.client.checkTokens(ctx, hashes)
-	if err != nil {
-		return fmt.Errorf("failed to check tokens: %w", err)
+	return nil
+}
+
+// credentialSource pairs a Credential with the TokenSource that produced it,
+// so the revocation pass can call back into the right source.
+type credentialSource struct {
+	cred   Credential
+	source TokenSource
+}
+
+// listActiveCredentials lists non-expired credentials across every
+// registered source, keyed by hash for the revocation pass.
+func (s *Service) listActiveCredentials(ctx context.Context) ([]string, map[string]credentialSource, error) {
+	hashes := make([]string, 0)
+	credsByHash := make(map[string]credentialSource)
+
+	for _, source := range s.sources {
+		creds, err := source.List(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list credentials: %w", err)
+		}
+
+		for _, c := range creds {
+			if c.hasExpired() {
+				continue
+			}
+
+			hashes = append(hashes, c.Hash)
+			credsByHash[c.Hash] = credentialSource{cred: c, source: source}
+		}
 	}
 
-	// Revoke leaked tokens.
-	// Could be done in bulk but we don't expect more than 1 or 2 tokens to be leaked per check.
-	for _, leakedTokenHash := range leakedTokenHashes {
-		leakedToken := hashMap[leakedTokenHash]
+	return hashes, credsByHash, nil
+}
 
-		if err := s.store.DeleteServiceAccountToken(
-			ctx, leakedToken.OrgId, *leakedToken.ServiceAccountId, leakedToken.Id); err != nil {
-			return fmt.Errorf("failed to delete leaked token: %w", err)
+// checkProviders fans hashes out to every configured provider, chunking each
+// call to respect the provider's batch size and bounding it with the
+// provider's own timeout. A hash flagged by more than one provider is
+// reported once, attributed to whichever provider flagged it first.
+func (s *Service) checkProviders(ctx context.Context, hashes []string) ([]LeakedHash, error) {
+	seen := make(map[string]bool)
+	var leaked []LeakedHash
+
+	for _, p := range s.providers {
+		for _, batch := range chunk(hashes, p.BatchSize()) {
+			found, err := s.checkBatch(ctx, p, batch)
+			if err != nil {
+				return nil, fmt.Errorf("provider %q: %w", p.Name(), err)
+			}
+
+			for _, h := range found {
+				if seen[h] {
+					continue
+				}
+				seen[h] = true
+				leaked = append(leaked, LeakedHash{Hash: h, Provider: p.Name()})
+			}
 		}
 	}
 
-	return nil
+	return leaked, nil
+}
+
+func (s *Service) checkBatch(ctx context.Context, p Provider, batch []string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout())
+	defer cancel()
+
+	return p.Check(ctx, batch)
 }
@@ -0,0 +1,97 @@
+package toucan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/services/serviceaccounts"
+)
+
+// CredentialKind identifies what a Credential actually is, so a subscriber
+// revoking or auditing it doesn't have to reach back into the store that
+// produced it.
+type CredentialKind string
+
+const (
+	CredentialKindSAToken        CredentialKind = "sa-token"
+	CredentialKindAPIKey         CredentialKind = "api-key"
+	CredentialKindDataSourceAuth CredentialKind = "datasource-auth"
+	CredentialKindSMTP           CredentialKind = "smtp"
+)
+
+// Credential is a single secret a TokenSource knows how to list and revoke.
+// It carries enough context for a caller to build a meaningful audit record
+// once it's revoked, without a round trip back to the source.
+type Credential struct {
+	Kind CredentialKind
+	Hash string
+
+	OrgID int64
+	// OwnerID is the ID of the thing the credential belongs to, e.g. the
+	// service account ID a token was minted for.
+	OwnerID int64
+	// Owner is a human-readable description of the owner, for logs and audit
+	// records, e.g. "service account 42".
+	Owner string
+	// ID is the credential's own ID within its source, e.g. the token ID.
+	ID int64
+
+	Expires *int64
+}
+
+func (c Credential) hasExpired() bool {
+	return hasExpired(c.Expires)
+}
+
+// TokenSource lists and revokes one kind of credential store. Each
+// credential store Grafana knows about (service account tokens, legacy API
+// keys, data source secrets, SMTP/alerting credentials, ...) registers one
+// implementation with the Service.
+type TokenSource interface {
+	List(ctx context.Context) ([]Credential, error)
+	Revoke(ctx context.Context, c Credential) error
+}
+
+// saTokenSource adapts SATokenRetriever, toucan's original credential store,
+// to TokenSource.
+type saTokenSource struct {
+	store SATokenRetriever
+}
+
+func newSATokenSource(store SATokenRetriever) *saTokenSource {
+	return &saTokenSource{store: store}
+}
+
+func (s *saTokenSource) List(ctx context.Context) ([]Credential, error) {
+	tokens, err := s.store.ListTokens(ctx, &serviceaccounts.GetSATokensQuery{
+		OrgID:            nil,
+		ServiceAccountID: nil,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve service account tokens: %w", err)
+	}
+
+	creds := make([]Credential, 0, len(tokens))
+	for _, t := range tokens {
+		var ownerID int64
+		if t.ServiceAccountId != nil {
+			ownerID = *t.ServiceAccountId
+		}
+
+		creds = append(creds, Credential{
+			Kind:    CredentialKindSAToken,
+			Hash:    t.Key,
+			OrgID:   t.OrgId,
+			OwnerID: ownerID,
+			Owner:   fmt.Sprintf("service account %d", ownerID),
+			ID:      t.Id,
+			Expires: t.Expires,
+		})
+	}
+
+	return creds, nil
+}
+
+func (s *saTokenSource) Revoke(ctx context.Context, c Credential) error {
+	return s.store.DeleteServiceAccountToken(ctx, c.OrgID, c.OwnerID, c.ID)
+}
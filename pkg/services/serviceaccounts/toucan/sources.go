@@ -0,0 +1,160 @@
+package toucan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/services/apikey"
+)
+
+// LegacyAPIKeyRetriever lists and revokes legacy (non-service-account) API
+// keys. Legacy keys share apikey.APIKey with service account tokens; they're
+// the ones with a nil ServiceAccountId.
+type LegacyAPIKeyRetriever interface {
+	ListAPIKeys(ctx context.Context, query *apikey.GetApiKeysQuery) ([]apikey.APIKey, error)
+	DeleteAPIKey(ctx context.Context, orgID, keyID int64) error
+}
+
+// legacyAPIKeySource adapts LegacyAPIKeyRetriever to TokenSource.
+type legacyAPIKeySource struct {
+	store LegacyAPIKeyRetriever
+}
+
+func newLegacyAPIKeySource(store LegacyAPIKeyRetriever) *legacyAPIKeySource {
+	return &legacyAPIKeySource{store: store}
+}
+
+func (s *legacyAPIKeySource) List(ctx context.Context) ([]Credential, error) {
+	keys, err := s.store.ListAPIKeys(ctx, &apikey.GetApiKeysQuery{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve legacy api keys: %w", err)
+	}
+
+	creds := make([]Credential, 0, len(keys))
+	for _, k := range keys {
+		if k.ServiceAccountId != nil {
+			// Service account tokens are also stored as apikey.APIKey rows,
+			// but saTokenSource already lists them and knows how to revoke
+			// them through the service account path. Skip them here so a
+			// leaked SA token isn't misrouted to DeleteAPIKey.
+			continue
+		}
+
+		creds = append(creds, Credential{
+			Kind:    CredentialKindAPIKey,
+			Hash:    k.Key,
+			OrgID:   k.OrgId,
+			OwnerID: k.Id,
+			Owner:   fmt.Sprintf("api key %q", k.Name),
+			ID:      k.Id,
+			Expires: k.Expires,
+		})
+	}
+
+	return creds, nil
+}
+
+func (s *legacyAPIKeySource) Revoke(ctx context.Context, c Credential) error {
+	return s.store.DeleteAPIKey(ctx, c.OrgID, c.ID)
+}
+
+// DataSourceSecret is a single basic-auth or bearer-token secret belonging
+// to a data source, as reported by a DataSourceSecretRetriever.
+type DataSourceSecret struct {
+	OrgID        int64
+	DataSourceID int64
+	Name         string
+	Hash         string
+}
+
+// DataSourceSecretRetriever lists and revokes data source basic-auth/bearer
+// secrets. Revoking clears the secret on the data source; it doesn't delete
+// the data source itself.
+type DataSourceSecretRetriever interface {
+	ListDataSourceSecrets(ctx context.Context) ([]DataSourceSecret, error)
+	RevokeDataSourceSecret(ctx context.Context, orgID, dataSourceID int64) error
+}
+
+// dataSourceSecretSource adapts DataSourceSecretRetriever to TokenSource.
+type dataSourceSecretSource struct {
+	store DataSourceSecretRetriever
+}
+
+func newDataSourceSecretSource(store DataSourceSecretRetriever) *dataSourceSecretSource {
+	return &dataSourceSecretSource{store: store}
+}
+
+func (s *dataSourceSecretSource) List(ctx context.Context) ([]Credential, error) {
+	secrets, err := s.store.ListDataSourceSecrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve data source secrets: %w", err)
+	}
+
+	creds := make([]Credential, 0, len(secrets))
+	for _, sec := range secrets {
+		creds = append(creds, Credential{
+			Kind:    CredentialKindDataSourceAuth,
+			Hash:    sec.Hash,
+			OrgID:   sec.OrgID,
+			OwnerID: sec.DataSourceID,
+			Owner:   fmt.Sprintf("data source %q", sec.Name),
+			ID:      sec.DataSourceID,
+		})
+	}
+
+	return creds, nil
+}
+
+func (s *dataSourceSecretSource) Revoke(ctx context.Context, c Credential) error {
+	return s.store.RevokeDataSourceSecret(ctx, c.OrgID, c.OwnerID)
+}
+
+// NotifierSecret is a single SMTP or alerting-notifier credential, as
+// reported by a NotifierSecretRetriever.
+type NotifierSecret struct {
+	OrgID      int64
+	NotifierID int64
+	Name       string
+	Hash       string
+}
+
+// NotifierSecretRetriever lists and revokes SMTP and alerting notifier
+// credentials.
+type NotifierSecretRetriever interface {
+	ListNotifierSecrets(ctx context.Context) ([]NotifierSecret, error)
+	RevokeNotifierSecret(ctx context.Context, orgID, notifierID int64) error
+}
+
+// notifierSecretSource adapts NotifierSecretRetriever to TokenSource.
+type notifierSecretSource struct {
+	store NotifierSecretRetriever
+}
+
+func newNotifierSecretSource(store NotifierSecretRetriever) *notifierSecretSource {
+	return &notifierSecretSource{store: store}
+}
+
+func (s *notifierSecretSource) List(ctx context.Context) ([]Credential, error) {
+	secrets, err := s.store.ListNotifierSecrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve notifier secrets: %w", err)
+	}
+
+	creds := make([]Credential, 0, len(secrets))
+	for _, sec := range secrets {
+		creds = append(creds, Credential{
+			Kind:    CredentialKindSMTP,
+			Hash:    sec.Hash,
+			OrgID:   sec.OrgID,
+			OwnerID: sec.NotifierID,
+			Owner:   fmt.Sprintf("notifier %q", sec.Name),
+			ID:      sec.NotifierID,
+		})
+	}
+
+	return creds, nil
+}
+
+func (s *notifierSecretSource) Revoke(ctx context.Context, c Credential) error {
+	return s.store.RevokeNotifierSecret(ctx, c.OrgID, c.OwnerID)
+}
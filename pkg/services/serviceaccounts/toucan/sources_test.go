@@ -0,0 +1,102 @@
+package toucan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/grafana/pkg/services/apikey"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLegacyAPIKeyRetriever struct {
+	keys    []apikey.APIKey
+	revoked []int64
+}
+
+func (f *fakeLegacyAPIKeyRetriever) ListAPIKeys(_ context.Context, _ *apikey.GetApiKeysQuery) ([]apikey.APIKey, error) {
+	return f.keys, nil
+}
+
+func (f *fakeLegacyAPIKeyRetriever) DeleteAPIKey(_ context.Context, _, keyID int64) error {
+	f.revoked = append(f.revoked, keyID)
+	return nil
+}
+
+func TestLegacyAPIKeySource(t *testing.T) {
+	saID := int64(42)
+	store := &fakeLegacyAPIKeyRetriever{keys: []apikey.APIKey{
+		{Id: 1, OrgId: 2, Name: "ci", Key: "hash-1"},
+		{Id: 2, OrgId: 2, Name: "sa-token", Key: "hash-2", ServiceAccountId: &saID},
+	}}
+	source := newLegacyAPIKeySource(store)
+
+	creds, err := source.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, creds, 1)
+	require.Equal(t, CredentialKindAPIKey, creds[0].Kind)
+	require.Equal(t, "hash-1", creds[0].Hash)
+	require.Equal(t, int64(2), creds[0].OrgID)
+
+	require.NoError(t, source.Revoke(context.Background(), creds[0]))
+	require.Equal(t, []int64{1}, store.revoked)
+}
+
+type fakeDataSourceSecretRetriever struct {
+	secrets []DataSourceSecret
+	revoked []int64
+}
+
+func (f *fakeDataSourceSecretRetriever) ListDataSourceSecrets(_ context.Context) ([]DataSourceSecret, error) {
+	return f.secrets, nil
+}
+
+func (f *fakeDataSourceSecretRetriever) RevokeDataSourceSecret(_ context.Context, _, dataSourceID int64) error {
+	f.revoked = append(f.revoked, dataSourceID)
+	return nil
+}
+
+func TestDataSourceSecretSource(t *testing.T) {
+	store := &fakeDataSourceSecretRetriever{secrets: []DataSourceSecret{
+		{OrgID: 1, DataSourceID: 9, Name: "prod-postgres", Hash: "hash-9"},
+	}}
+	source := newDataSourceSecretSource(store)
+
+	creds, err := source.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, creds, 1)
+	require.Equal(t, CredentialKindDataSourceAuth, creds[0].Kind)
+	require.Equal(t, int64(9), creds[0].OwnerID)
+
+	require.NoError(t, source.Revoke(context.Background(), creds[0]))
+	require.Equal(t, []int64{9}, store.revoked)
+}
+
+type fakeNotifierSecretRetriever struct {
+	secrets []NotifierSecret
+	revoked []int64
+}
+
+func (f *fakeNotifierSecretRetriever) ListNotifierSecrets(_ context.Context) ([]NotifierSecret, error) {
+	return f.secrets, nil
+}
+
+func (f *fakeNotifierSecretRetriever) RevokeNotifierSecret(_ context.Context, _, notifierID int64) error {
+	f.revoked = append(f.revoked, notifierID)
+	return nil
+}
+
+func TestNotifierSecretSource(t *testing.T) {
+	store := &fakeNotifierSecretRetriever{secrets: []NotifierSecret{
+		{OrgID: 1, NotifierID: 4, Name: "smtp-relay", Hash: "hash-4"},
+	}}
+	source := newNotifierSecretSource(store)
+
+	creds, err := source.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, creds, 1)
+	require.Equal(t, CredentialKindSMTP, creds[0].Kind)
+	require.Equal(t, int64(4), creds[0].OwnerID)
+
+	require.NoError(t, source.Revoke(context.Background(), creds[0]))
+	require.Equal(t, []int64{4}, store.revoked)
+}